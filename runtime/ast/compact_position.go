@@ -0,0 +1,77 @@
+//go:build compactposition
+
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+// CompactPosition packs a line and column number into a single 32-bit
+// word, 16 bits each, instead of the three machine words (and, with
+// FileID, the fourth) that Position spends on the same information.
+// It is built for embedders that lex huge inputs one token at a time
+// and want to cut the per-token position cost, at the price of range:
+// a CompactPosition cannot represent an offset, or a line or column
+// past 65535.
+//
+// It is opt-in, gated behind the "compactposition" build tag, so that
+// it costs nothing -- not even compiling -- to code that doesn't ask
+// for it. The lexer and AST continue to use the wide Position by
+// default; a caller that wants CompactPosition builds with
+// "-tags compactposition" and converts with NewCompactPosition or
+// CompactPositionFromPosition.
+type CompactPosition uint32
+
+// maxCompactComponent is the largest line or column number a
+// CompactPosition can represent; larger values saturate at it rather
+// than wrapping around.
+const maxCompactComponent = 0xFFFF
+
+// NewCompactPosition packs the given line and column into a
+// CompactPosition. A negative value, or a value greater than 65535,
+// saturates at 0 or 65535 respectively, rather than wrapping.
+func NewCompactPosition(line, column int) CompactPosition {
+	return CompactPosition(saturateUint16(line))<<16 | CompactPosition(saturateUint16(column))
+}
+
+// CompactPositionFromPosition packs the Line and Column of a wide
+// Position into a CompactPosition. The Offset and FileID are not
+// representable and are discarded.
+func CompactPositionFromPosition(p Position) CompactPosition {
+	return NewCompactPosition(p.Line, p.Column)
+}
+
+// Line returns the line number packed into the CompactPosition.
+func (p CompactPosition) Line() int {
+	return int(p >> 16)
+}
+
+// Column returns the column number packed into the CompactPosition.
+func (p CompactPosition) Column() int {
+	return int(p & maxCompactComponent)
+}
+
+func saturateUint16(n int) CompactPosition {
+	switch {
+	case n < 0:
+		return 0
+	case n > maxCompactComponent:
+		return maxCompactComponent
+	default:
+		return CompactPosition(n)
+	}
+}
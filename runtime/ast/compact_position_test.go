@@ -0,0 +1,54 @@
+//go:build compactposition
+
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCompactPosition(t *testing.T) {
+
+	t.Run("round-trips a position within range", func(t *testing.T) {
+		p := NewCompactPosition(12, 34)
+		assert.Equal(t, 12, p.Line())
+		assert.Equal(t, 34, p.Column())
+	})
+
+	t.Run("saturates a line and column past 65535", func(t *testing.T) {
+		p := NewCompactPosition(100_000, 70_000)
+		assert.Equal(t, 0xFFFF, p.Line())
+		assert.Equal(t, 0xFFFF, p.Column())
+	})
+
+	t.Run("saturates a negative line or column at 0", func(t *testing.T) {
+		p := NewCompactPosition(-1, -1)
+		assert.Equal(t, 0, p.Line())
+		assert.Equal(t, 0, p.Column())
+	})
+}
+
+func TestCompactPositionFromPosition(t *testing.T) {
+	p := CompactPositionFromPosition(Position{Line: 3, Column: 7, Offset: 42})
+	assert.Equal(t, 3, p.Line())
+	assert.Equal(t, 7, p.Column())
+}
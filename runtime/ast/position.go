@@ -0,0 +1,73 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+// Position describes a position in the source, in line and column number,
+// as well as the absolute byte offset, and the source file it belongs to.
+//
+// FileID is the zero value for any single-file program, so code that only
+// ever lexes one source (the common case, and every use prior to the
+// introduction of FileID) does not need to think about it at all.
+type Position struct {
+	Offset int
+	Line   int
+	Column int
+	FileID FileID
+}
+
+// HasPosition is implemented by AST nodes that have a start and end position.
+type HasPosition interface {
+	StartPosition() Position
+	EndPosition() Position
+}
+
+// Shifted returns a new position shifted by the given number of bytes,
+// assuming none of them is a newline.
+func (p Position) Shifted(length int) Position {
+	return Position{
+		Offset: p.Offset + length,
+		Line:   p.Line,
+		Column: p.Column + length,
+		FileID: p.FileID,
+	}
+}
+
+// AdvanceLine returns a new position at the start of the next line.
+func (p Position) AdvanceLine() Position {
+	return Position{
+		Offset: p.Offset + 1,
+		Line:   p.Line + 1,
+		Column: 0,
+		FileID: p.FileID,
+	}
+}
+
+// Range describes a start and end position in the source.
+type Range struct {
+	StartPos Position
+	EndPos   Position
+}
+
+func (r Range) StartPosition() Position {
+	return r.StartPos
+}
+
+func (r Range) EndPosition() Position {
+	return r.EndPos
+}
@@ -0,0 +1,66 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+// FileID identifies the source file a Position belongs to. It is a small
+// integer, interned through a SourceMap, so that carrying a file's
+// identity on every token and position costs a few bytes rather than a
+// copy of the file's name.
+//
+// The zero FileID is the first file interned into a given SourceMap; a
+// lone source (no imports, no multi-file program) never has to think
+// about FileID at all.
+type FileID int
+
+// SourceMap interns source file names (paths, import locations) into
+// compact FileIDs, so that once a program spans more than one file --
+// a transaction plus the contracts it imports -- diagnostics can say
+// which file a token came from without repeating its name per token.
+//
+// A SourceMap is not safe for concurrent use.
+type SourceMap struct {
+	idsByName map[string]FileID
+	namesByID []string
+}
+
+// NewSourceMap creates an empty SourceMap.
+func NewSourceMap() *SourceMap {
+	return &SourceMap{
+		idsByName: make(map[string]FileID),
+	}
+}
+
+// Intern returns the FileID for the given source name, interning it
+// (assigning it the next unused FileID) if this is the first time the
+// name has been seen by this SourceMap.
+func (m *SourceMap) Intern(name string) FileID {
+	if id, ok := m.idsByName[name]; ok {
+		return id
+	}
+
+	id := FileID(len(m.namesByID))
+	m.idsByName[name] = id
+	m.namesByID = append(m.namesByID, name)
+	return id
+}
+
+// Name returns the source name that was interned as the given FileID.
+func (m *SourceMap) Name(id FileID) string {
+	return m.namesByID[id]
+}
@@ -0,0 +1,90 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+// TriviaPosition names a position within a node that trivia (whitespace
+// and comments) can be attached to. Which positions are meaningful
+// depends on the kind of node: a declaration has a Start, a Name, and an
+// End, while a binary expression might only have the positions around
+// its operator.
+//
+// No parser in this module builds AST nodes and attaches trivia to them
+// yet (parser2/trivia.go only pairs tokens with their leading trivia, not
+// with a node); TriviaPositionEnd and TriviaPositionName exist ahead of
+// that so FreeFloating's API doesn't need to change shape once it does.
+type TriviaPosition string
+
+const (
+	// TriviaPositionStart is the trivia immediately before a node begins.
+	TriviaPositionStart TriviaPosition = "Start"
+	// TriviaPositionEnd is the trivia immediately after a node ends.
+	TriviaPositionEnd TriviaPosition = "End"
+	// TriviaPositionName is the trivia surrounding a node's name, for
+	// node kinds that have one.
+	TriviaPositionName TriviaPosition = "Name"
+)
+
+// TriviaType distinguishes the kinds of trivia that can be attached to a
+// node.
+type TriviaType int
+
+const (
+	TriviaSpace TriviaType = iota
+	TriviaLineComment
+	TriviaBlockComment
+)
+
+// Trivia is a single piece of free-floating trivia: whitespace or a
+// comment that carries no syntactic meaning, but that downstream tools
+// (formatters, linters, refactoring tools) need in order to reproduce the
+// original source exactly.
+//
+// Trivia deliberately does not reuse lexer.Token: this package is
+// imported by the lexer package (for Position and Range), so depending on
+// the lexer package here would introduce an import cycle. The parser is
+// responsible for converting lexer tokens to Trivia values when it
+// attaches them to a node.
+type Trivia struct {
+	Type  TriviaType
+	Value string
+	Range Range
+}
+
+// FreeFloating holds the trivia attached to the positions of a node, as a
+// type an AST node can embed. None of the AST node types in this package
+// embeds it at present. It is not itself a Node, since not every node that
+// wants trivia attachment also wants the rest of a Node's fields.
+type FreeFloating struct {
+	freeFloating map[TriviaPosition][]Trivia
+}
+
+// SetFreeFloating attaches the given trivia to the given position of the
+// node, replacing any trivia previously attached there.
+func (f *FreeFloating) SetFreeFloating(position TriviaPosition, trivia []Trivia) {
+	if f.freeFloating == nil {
+		f.freeFloating = make(map[TriviaPosition][]Trivia)
+	}
+	f.freeFloating[position] = trivia
+}
+
+// GetFreeFloating returns the trivia attached to the given position of
+// the node, or nil if none was attached.
+func (f *FreeFloating) GetFreeFloating(position TriviaPosition) []Trivia {
+	return f.freeFloating[position]
+}
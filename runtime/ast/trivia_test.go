@@ -0,0 +1,65 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFreeFloating(t *testing.T) {
+
+	t.Run("unset position returns nil", func(t *testing.T) {
+		var f FreeFloating
+		assert.Nil(t, f.GetFreeFloating(TriviaPositionStart))
+	})
+
+	t.Run("set and get", func(t *testing.T) {
+		var f FreeFloating
+
+		trivia := []Trivia{
+			{
+				Type:  TriviaLineComment,
+				Value: "// hello",
+				Range: Range{
+					StartPos: Position{Line: 1, Column: 0, Offset: 0},
+					EndPos:   Position{Line: 1, Column: 8, Offset: 8},
+				},
+			},
+		}
+
+		f.SetFreeFloating(TriviaPositionStart, trivia)
+
+		assert.Equal(t, trivia, f.GetFreeFloating(TriviaPositionStart))
+		assert.Nil(t, f.GetFreeFloating(TriviaPositionEnd))
+	})
+
+	t.Run("overwriting a position replaces its trivia", func(t *testing.T) {
+		var f FreeFloating
+
+		f.SetFreeFloating(TriviaPositionEnd, []Trivia{{Type: TriviaSpace, Value: " "}})
+		f.SetFreeFloating(TriviaPositionEnd, []Trivia{{Type: TriviaSpace, Value: "  "}})
+
+		assert.Equal(t,
+			[]Trivia{{Type: TriviaSpace, Value: "  "}},
+			f.GetFreeFloating(TriviaPositionEnd),
+		)
+	})
+}
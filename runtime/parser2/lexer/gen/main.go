@@ -0,0 +1,528 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Command gen generates lexer_table.go, the transition table that drives
+// lexer.rootState: for every (state, byte) pair it says which state
+// scanning should move to next, so that rootState itself only has to walk
+// transitionTarget[state][byte] in a loop.
+//
+// The table is derived from the declarative state list below: each
+// stateDef names a state, the explicit byte rules that leave it, what to
+// do by default when no rule matches, and what token (if any) it accepts.
+// That data is the lexer's grammar; changing the grammar means editing
+// stateDefs and re-running go generate, not hand-editing dispatch code.
+//
+// One construct can't be expressed this way: nested block comments, since
+// matching arbitrary nesting depth needs a counter, and no finite state
+// table can count. Rather than fake it, a rule can target blockCommentEntry,
+// which rootState recognizes as a handoff to the small hand-written
+// blockCommentState loop instead of a generated state.
+//
+// Run via `go generate ./...` from the lexer package (see gen.go).
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"sort"
+)
+
+// byteRule maps a set of bytes leaving a state to the state (or control
+// target) they lead to.
+type byteRule struct {
+	bytes  []byte
+	target string
+}
+
+// stateDef declares one state of the generated DFA.
+type stateDef struct {
+	// name is the generated constant name, e.g. "stateIdentifier".
+	name string
+
+	// rules are tried in order; the first one containing the byte wins.
+	rules []byteRule
+
+	// defaultTarget is used for a byte no rule matches. It is always one
+	// of: "stop" (finish the token without consuming the byte), the
+	// state's own name (self-loop, i.e. "anything else continues this
+	// state"), or another state's name.
+	defaultTarget string
+
+	// immediate marks a state that is itself a complete token the
+	// instant it's entered, with no further lookahead needed (the
+	// single case rootState special-cases): "(" , "->", "==", and so on.
+	immediate bool
+
+	// accept, if true, means reaching "stop" (by rule or by default, or
+	// by EOF) in this state completes a token of acceptType. If false,
+	// reaching "stop" is a lexical error, described by errorMessage (or
+	// a generic "unexpected character" message if errorMessage is "").
+	accept       bool
+	acceptType   string
+	errorMessage string
+
+	// hasValue marks an accepting state whose token carries the scanned
+	// text as its Value (numbers, identifiers, strings, space, and
+	// comments); every other accepted or immediate token's Value is nil,
+	// since its type already says everything about it.
+	hasValue bool
+}
+
+// Byte-set builders, used to keep stateDefs declarative instead of
+// spelling out every byte of every character class by hand.
+
+func byteRange(lo, hi byte) []byte {
+	bs := make([]byte, 0, int(hi-lo)+1)
+	for b := lo; b <= hi; b++ {
+		bs = append(bs, b)
+	}
+	return bs
+}
+
+func bytesOf(set ...[]byte) []byte {
+	var all []byte
+	for _, s := range set {
+		all = append(all, s...)
+	}
+	return all
+}
+
+func digits() []byte { return byteRange('0', '9') }
+
+func hexDigits() []byte {
+	return bytesOf(digits(), byteRange('a', 'f'), byteRange('A', 'F'))
+}
+
+func letters() []byte {
+	return bytesOf(byteRange('a', 'z'), byteRange('A', 'Z'), []byte{'_'})
+}
+
+func identifierPart() []byte {
+	return bytesOf(letters(), digits())
+}
+
+func spaceBytes() []byte {
+	return []byte{' ', '\t', '\n', '\r'}
+}
+
+func digitsAndSeparator() []byte {
+	return bytesOf(digits(), []byte{'_'})
+}
+
+// stateDefs is the lexer's grammar: one entry per state of the DFA,
+// reproducing every token the hand-written lexer used to scan directly.
+//
+// States are listed start-to-finish through a token: stateStart dispatches
+// on the first byte, and every other state is reached only by a rule
+// naming it, so the order below is also roughly the order each family of
+// tokens is scanned in.
+var stateDefs = []stateDef{
+	{
+		name:          "stateStart",
+		defaultTarget: "stop",
+		rules: []byteRule{
+			{spaceBytes(), "stateSpace"},
+			{[]byte{'0'}, "stateZero"},
+			{byteRange('1', '9'), "stateDecInt"},
+			{letters(), "stateIdentifier"},
+			{[]byte{'"'}, "stateString"},
+			{[]byte{'('}, "stateParenOpen"},
+			{[]byte{')'}, "stateParenClose"},
+			{[]byte{'+'}, "stateOpPlus"},
+			{[]byte{'-'}, "stateMinus"},
+			{[]byte{'*'}, "stateOpMul"},
+			{[]byte{'/'}, "stateSlash"},
+			{[]byte{'?'}, "stateQuestion"},
+			{[]byte{'<'}, "stateLess"},
+			{[]byte{'>'}, "stateGreater"},
+			{[]byte{'='}, "stateEqual"},
+			{[]byte{'!'}, "stateBang"},
+		},
+	},
+
+	// whitespace
+	{
+		name:          "stateSpace",
+		defaultTarget: "stop",
+		rules:         []byteRule{{spaceBytes(), "stateSpace"}},
+		accept:        true, acceptType: "TokenSpace", hasValue: true,
+	},
+
+	// numbers: decimal (with underscore separators, fraction, exponent)
+	// and 0x/0b/0o-prefixed integers, all permissive about trailing
+	// zero-digit prefixes and bare exponents.
+	{
+		name:          "stateZero",
+		defaultTarget: "stop",
+		rules: []byteRule{
+			{[]byte{'x'}, "stateHexDigits"},
+			{[]byte{'b'}, "stateBinDigits"},
+			{[]byte{'o'}, "stateOctDigits"},
+			{digitsAndSeparator(), "stateDecInt"},
+			{[]byte{'.'}, "stateFracDigits"},
+			{[]byte{'e', 'E'}, "stateExpNoDigits"},
+		},
+		accept: true, acceptType: "TokenNumber", hasValue: true,
+	},
+	{
+		name:          "stateDecInt",
+		defaultTarget: "stop",
+		rules: []byteRule{
+			{digitsAndSeparator(), "stateDecInt"},
+			{[]byte{'.'}, "stateFracDigits"},
+			{[]byte{'e', 'E'}, "stateExpNoDigits"},
+		},
+		accept: true, acceptType: "TokenNumber", hasValue: true,
+	},
+	{
+		name:          "stateHexDigits",
+		defaultTarget: "stop",
+		rules:         []byteRule{{bytesOf(hexDigits(), []byte{'_'}), "stateHexDigits"}},
+		accept:        true, acceptType: "TokenNumber", hasValue: true,
+	},
+	{
+		name:          "stateBinDigits",
+		defaultTarget: "stop",
+		rules:         []byteRule{{[]byte{'0', '1', '_'}, "stateBinDigits"}},
+		accept:        true, acceptType: "TokenNumber", hasValue: true,
+	},
+	{
+		name:          "stateOctDigits",
+		defaultTarget: "stop",
+		rules:         []byteRule{{bytesOf(byteRange('0', '7'), []byte{'_'}), "stateOctDigits"}},
+		accept:        true, acceptType: "TokenNumber", hasValue: true,
+	},
+	{
+		name:          "stateFracDigits",
+		defaultTarget: "stop",
+		rules: []byteRule{
+			{digitsAndSeparator(), "stateFracDigits"},
+			{[]byte{'e', 'E'}, "stateExpNoDigits"},
+		},
+		accept: true, acceptType: "TokenNumber", hasValue: true,
+	},
+	{
+		name:          "stateExpNoDigits",
+		defaultTarget: "stop",
+		rules: []byteRule{
+			{[]byte{'+', '-'}, "stateExpDigits"},
+			{digitsAndSeparator(), "stateExpDigits"},
+		},
+		accept: true, acceptType: "TokenNumber", hasValue: true,
+	},
+	{
+		name:          "stateExpDigits",
+		defaultTarget: "stop",
+		rules:         []byteRule{{digitsAndSeparator(), "stateExpDigits"}},
+		accept:        true, acceptType: "TokenNumber", hasValue: true,
+	},
+
+	// identifiers
+	{
+		name:          "stateIdentifier",
+		defaultTarget: "stop",
+		rules:         []byteRule{{identifierPart(), "stateIdentifier"}},
+		accept:        true, acceptType: "TokenIdentifier", hasValue: true,
+	},
+
+	// strings
+	{
+		name:          "stateString",
+		defaultTarget: "stateString",
+		rules: []byteRule{
+			{[]byte{'"'}, "stateStringEnd"},
+			{[]byte{'\\'}, "stateStringEscape"},
+		},
+		accept: false, errorMessage: "missing string end",
+	},
+	{
+		name:          "stateStringEscape",
+		defaultTarget: "stateString",
+		accept:        false, errorMessage: "missing string end",
+	},
+	{
+		name: "stateStringEnd", immediate: true, acceptType: "TokenString", hasValue: true,
+	},
+
+	// single-character tokens
+	{name: "stateParenOpen", immediate: true, acceptType: "TokenParenOpen"},
+	{name: "stateParenClose", immediate: true, acceptType: "TokenParenClose"},
+	{name: "stateOpPlus", immediate: true, acceptType: "TokenOperatorPlus"},
+	{name: "stateOpMul", immediate: true, acceptType: "TokenOperatorMul"},
+
+	// '-' and "->"
+	{
+		name:          "stateMinus",
+		defaultTarget: "stop",
+		rules:         []byteRule{{[]byte{'>'}, "stateArrow"}},
+		accept:        true, acceptType: "TokenOperatorMinus",
+	},
+	{name: "stateArrow", immediate: true, acceptType: "TokenArrow"},
+
+	// '/', "//" line comments, and "/*" block comments
+	{
+		name:          "stateSlash",
+		defaultTarget: "stop",
+		rules: []byteRule{
+			{[]byte{'/'}, "stateLineComment"},
+			{[]byte{'*'}, "blockCommentEntry"},
+		},
+		accept: true, acceptType: "TokenOperatorDiv",
+	},
+	{
+		name:          "stateLineComment",
+		defaultTarget: "stateLineComment",
+		rules:         []byteRule{{[]byte{'\n'}, "stop"}},
+		accept:        true, acceptType: "TokenLineComment", hasValue: true,
+	},
+
+	// '??' nil-coalescing; a lone '?' is a lexical error
+	{
+		name:          "stateQuestion",
+		defaultTarget: "stop",
+		rules:         []byteRule{{[]byte{'?'}, "stateNilCoalesce"}},
+		accept:        false, errorMessage: "expected character: U+003F '?'",
+	},
+	{name: "stateNilCoalesce", immediate: true, acceptType: "TokenOperatorNilCoalesce"},
+
+	// '<', "<-", "<=", "<<"
+	{
+		name:          "stateLess",
+		defaultTarget: "stop",
+		rules: []byteRule{
+			{[]byte{'-'}, "stateLeftArrow"},
+			{[]byte{'='}, "stateLessEqual"},
+			{[]byte{'<'}, "stateShiftLeft"},
+		},
+		accept: true, acceptType: "TokenLess",
+	},
+	{name: "stateLeftArrow", immediate: true, acceptType: "TokenLeftArrow"},
+	{name: "stateLessEqual", immediate: true, acceptType: "TokenLessEqual"},
+	{name: "stateShiftLeft", immediate: true, acceptType: "TokenShiftLeft"},
+
+	// '>', ">=", ">>"
+	{
+		name:          "stateGreater",
+		defaultTarget: "stop",
+		rules: []byteRule{
+			{[]byte{'='}, "stateGreaterEqual"},
+			{[]byte{'>'}, "stateShiftRight"},
+		},
+		accept: true, acceptType: "TokenGreater",
+	},
+	{name: "stateGreaterEqual", immediate: true, acceptType: "TokenGreaterEqual"},
+	{name: "stateShiftRight", immediate: true, acceptType: "TokenShiftRight"},
+
+	// '=', "=="
+	{
+		name:          "stateEqual",
+		defaultTarget: "stop",
+		rules:         []byteRule{{[]byte{'='}, "stateEqualEqual"}},
+		accept:        true, acceptType: "TokenEqual",
+	},
+	{name: "stateEqualEqual", immediate: true, acceptType: "TokenEqualEqual"},
+
+	// '!', "!="
+	{
+		name:          "stateBang",
+		defaultTarget: "stop",
+		rules:         []byteRule{{[]byte{'='}, "stateNotEqual"}},
+		accept:        true, acceptType: "TokenNot",
+	},
+	{name: "stateNotEqual", immediate: true, acceptType: "TokenNotEqual"},
+}
+
+func main() {
+	n := len(stateDefs) + 3
+
+	var buf bytes.Buffer
+	buf.WriteString(fileHeader)
+	buf.WriteString("package lexer\n\n")
+
+	buf.WriteString("// lexState is a state of the generated lexer DFA. stateNone and\n")
+	buf.WriteString("// stateStop are control values, never a state rootState dispatches from;\n")
+	buf.WriteString("// stateBlockCommentEntry is a handoff to blockCommentState, the one part\n")
+	buf.WriteString("// of the grammar that isn't table-driven. See gen/main.go.\n")
+	buf.WriteString("type lexState int\n\n")
+
+	buf.WriteString("const (\n")
+	buf.WriteString("\tstateNone lexState = iota\n")
+	buf.WriteString("\tstateStop\n")
+	buf.WriteString("\tstateBlockCommentEntry\n\n")
+	for _, def := range stateDefs {
+		fmt.Fprintf(&buf, "\t%s\n", def.name)
+	}
+	buf.WriteString(")\n\n")
+
+	buf.WriteString("// transitionTarget[state][b] is the state scanning moves to after reading\n")
+	buf.WriteString("// byte b in the given state, or stateNone if no rule applies (in which\n")
+	buf.WriteString("// case stateDefaultTarget[state] applies instead).\n")
+	fmt.Fprintf(&buf, "var transitionTarget = [%d][256]lexState{\n", n)
+	for _, def := range stateDefs {
+		if len(def.rules) == 0 {
+			continue
+		}
+		fmt.Fprintf(&buf, "\t%s: {\n", def.name)
+		// a byte already claimed by an earlier rule of this state keeps
+		// that rule's target
+		seen := make(map[byte]bool)
+		for _, rule := range def.rules {
+			bs := append([]byte(nil), rule.bytes...)
+			sort.Slice(bs, func(i, j int) bool { return bs[i] < bs[j] })
+			target := resolveTargetName(rule.target)
+			for _, b := range bs {
+				if seen[b] {
+					continue
+				}
+				seen[b] = true
+				fmt.Fprintf(&buf, "\t\t%d: %s, // %q\n", b, target, rune(b))
+			}
+		}
+		buf.WriteString("\t},\n")
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// stateDefaultTarget[state] is the state scanning moves to on a byte\n")
+	buf.WriteString("// transitionTarget[state] has no rule for: either the state's own name,\n")
+	buf.WriteString("// for a state that continues itself on any byte it has no more specific\n")
+	buf.WriteString("// rule for (like a string body), or stateStop, for a state where any\n")
+	buf.WriteString("// unlisted byte ends the current token.\n")
+	fmt.Fprintf(&buf, "var stateDefaultTarget = [%d]lexState{\n", n)
+	for _, def := range stateDefs {
+		// immediate states never consult their default: rootState emits
+		// and returns to stateStart as soon as one is reached.
+		if def.immediate {
+			fmt.Fprintf(&buf, "\t%s: stateStop,\n", def.name)
+			continue
+		}
+		fmt.Fprintf(&buf, "\t%s: %s,\n", def.name, resolveTargetName(def.defaultTarget))
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// stateImmediate marks a state that is already a complete token the\n")
+	buf.WriteString("// instant it's entered (a multi-character operator like \"->\" whose last\n")
+	buf.WriteString("// character disambiguates it), so rootState emits it without reading\n")
+	buf.WriteString("// another byte first.\n")
+	fmt.Fprintf(&buf, "var stateImmediate = [%d]bool{\n", n)
+	for _, def := range stateDefs {
+		if def.immediate {
+			fmt.Fprintf(&buf, "\t%s: true,\n", def.name)
+		}
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// stateAccepts reports whether stopping in this state (by rule, by\n")
+	buf.WriteString("// default, or at EOF) completes a valid token, as opposed to a lexical\n")
+	buf.WriteString("// error.\n")
+	fmt.Fprintf(&buf, "var stateAccepts = [%d]bool{\n", n)
+	for _, def := range stateDefs {
+		if def.accept || def.immediate {
+			fmt.Fprintf(&buf, "\t%s: true,\n", def.name)
+		}
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// stateAcceptType is the token type emitted for a state in stateAccepts\n")
+	buf.WriteString("// (or stateImmediate).\n")
+	fmt.Fprintf(&buf, "var stateAcceptType = [%d]TokenType{\n", n)
+	for _, def := range stateDefs {
+		if def.acceptType != "" {
+			fmt.Fprintf(&buf, "\t%s: %s,\n", def.name, def.acceptType)
+		}
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// stateErrorMessage overrides the generic \"unexpected character\" error\n")
+	buf.WriteString("// for a non-accepting state that has a more specific complaint.\n")
+	fmt.Fprintf(&buf, "var stateErrorMessage = [%d]string{\n", n)
+	for _, def := range stateDefs {
+		if def.errorMessage != "" {
+			fmt.Fprintf(&buf, "\t%s: %q,\n", def.name, def.errorMessage)
+		}
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// stateHasValue marks a state whose token's Value is the text it\n")
+	buf.WriteString("// scanned; every other token's Value is nil, since its type already\n")
+	buf.WriteString("// says everything about it.\n")
+	fmt.Fprintf(&buf, "var stateHasValue = [%d]bool{\n", n)
+	for _, def := range stateDefs {
+		if def.hasValue {
+			fmt.Fprintf(&buf, "\t%s: true,\n", def.name)
+		}
+	}
+	buf.WriteString("}\n")
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "gen: formatting lexer_table.go:", err)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile("lexer_table.go", formatted, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "gen: writing lexer_table.go:", err)
+		os.Exit(1)
+	}
+}
+
+// resolveTargetName validates a rule or default target against the set of
+// defined states and control values, and returns the Go identifier to
+// emit for it.
+func resolveTargetName(name string) string {
+	switch name {
+	case "stop":
+		return "stateStop"
+	case "blockCommentEntry":
+		return "stateBlockCommentEntry"
+	case "":
+		fmt.Fprintln(os.Stderr, "gen: empty target name")
+		os.Exit(1)
+	}
+	for _, def := range stateDefs {
+		if def.name == name {
+			return name
+		}
+	}
+	fmt.Fprintf(os.Stderr, "gen: state %q is not defined\n", name)
+	os.Exit(1)
+	return ""
+}
+
+const fileHeader = `// Code generated by "go run ./gen". DO NOT EDIT.
+
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+`
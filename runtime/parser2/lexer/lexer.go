@@ -0,0 +1,407 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package lexer implements a lexer for the Cadence programming language.
+//
+// The lexer follows the design described by Rob Pike in "Lexical Scanning
+// in Go": it is a state machine of stateFn values, each of which scans a
+// chunk of input and returns the stateFn that should run next.
+//
+// The scanning itself is synchronous and pull-based: Lexer.Next scans just
+// enough of the input to produce a single token. Lex is a convenience
+// wrapper around Lexer that emits tokens on a channel, for callers that
+// are not yet converted to the pull-based API.
+package lexer
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/onflow/cadence/runtime/ast"
+)
+
+// EOF is returned by lexer.next when the end of the input has been reached.
+const EOF = rune(-1)
+
+// stateFn is a function that scans part of the input and returns the state
+// that should be used to scan the remainder, or nil if scanning is done.
+type stateFn func(*lexer) stateFn
+
+// lexer holds the state for scanning Cadence source code into tokens,
+// one at a time, on demand.
+type lexer struct {
+	// input is the entire source being scanned.
+	input string
+
+	// startOffset is the byte offset of the start of the token
+	// currently being scanned.
+	startOffset int
+	// endOffset is the byte offset of the next rune to be scanned.
+	endOffset int
+	// prevWidth is the width in bytes of the most recently scanned rune,
+	// used by backup to undo a single call to next.
+	prevWidth int
+
+	// startPos is the position of the start of the token currently
+	// being scanned.
+	startPos ast.Position
+	// endPos is the position of the next rune to be scanned.
+	endPos ast.Position
+	// prevEndPos is the value endPos had before the most recent call to
+	// next, used to restore it on backup.
+	prevEndPos ast.Position
+
+	// state is the state function that will resume scanning on the next
+	// call to scan, or nil once a terminal token (TokenEOF or TokenError)
+	// has been produced.
+	state stateFn
+	// tokenReady is set by emit to signal that pendingToken holds the
+	// token produced by the state function scan is currently running.
+	tokenReady bool
+	// pendingToken holds the token most recently produced by emit, until
+	// scan picks it up.
+	pendingToken Token
+	// lastToken holds the most recently scanned terminal token (TokenEOF
+	// or TokenError), so that scan can keep returning it once state
+	// becomes nil, instead of re-scanning exhausted input.
+	lastToken Token
+}
+
+// newLexer creates a lexer ready to scan the given input, starting at the
+// root state. file identifies the source the input came from, and is
+// carried on every position the lexer produces.
+func newLexer(input string, file ast.FileID) *lexer {
+	startingPos := ast.Position{Line: 1, FileID: file}
+	return &lexer{
+		input:    input,
+		state:    rootState,
+		startPos: startingPos,
+		endPos:   startingPos,
+	}
+}
+
+// sourceMap interns the source names passed to NewLexer and Lex into
+// FileIDs, so that a Token.Range can say which file it came from at the
+// cost of one small integer rather than a copy of the file's name.
+//
+// It is guarded by sourceMapMutex because, unlike a lexer itself, it is
+// shared by every call to NewLexer and Lex, which may run on different
+// goroutines (Lex in particular always does).
+var sourceMap = ast.NewSourceMap()
+var sourceMapMutex sync.Mutex
+
+func internSource(source string) ast.FileID {
+	sourceMapMutex.Lock()
+	defer sourceMapMutex.Unlock()
+	return sourceMap.Intern(source)
+}
+
+// SourceName returns the source name that was interned as the given
+// FileID by a previous call to NewLexer or Lex.
+func SourceName(file ast.FileID) string {
+	sourceMapMutex.Lock()
+	defer sourceMapMutex.Unlock()
+	return sourceMap.Name(file)
+}
+
+// scan runs state functions until one of them emits a token, and returns
+// that token. Once a terminal token has been emitted, scan keeps returning
+// it without re-scanning the (exhausted) input.
+func (l *lexer) scan() Token {
+	for l.state != nil {
+		l.state = l.state(l)
+		if l.tokenReady {
+			l.tokenReady = false
+			l.lastToken = l.pendingToken
+			return l.pendingToken
+		}
+	}
+	return l.lastToken
+}
+
+// Lexer is a synchronous, pull-based lexer: Next scans and returns tokens
+// of the input one at a time, on demand, with no goroutine or channel
+// involved. Peek and Backup give callers a one-token lookahead, the way
+// recursive-descent parsers need it.
+type Lexer struct {
+	lexer *lexer
+	// buffered holds a token that was returned by Next and then given
+	// back via Backup, to be returned again by the next call to Next.
+	buffered    Token
+	hasBuffered bool
+}
+
+// NewLexer creates a new pull-based lexer for the given input. source
+// identifies where the input came from (typically a file path); it is
+// interned into a small ast.FileID that every token's Range carries, so
+// that once a program spans more than one file (a transaction plus the
+// contracts it imports) diagnostics can say which one a token is from.
+// No scanning happens until Next or Peek is called.
+func NewLexer(input string, source string) *Lexer {
+	return &Lexer{lexer: newLexer(input, internSource(source))}
+}
+
+// Next returns the next token of the input, scanning it on demand.
+// Once a TokenEOF or TokenError token has been returned, further calls
+// keep returning that same token.
+func (l *Lexer) Next() Token {
+	if l.hasBuffered {
+		l.hasBuffered = false
+		return l.buffered
+	}
+	return l.lexer.scan()
+}
+
+// Peek returns the next token of the input without consuming it:
+// the following call to Next (or Peek) will return the same token.
+func (l *Lexer) Peek() Token {
+	if !l.hasBuffered {
+		l.buffered = l.lexer.scan()
+		l.hasBuffered = true
+	}
+	return l.buffered
+}
+
+// Backup un-reads the given token, so that the next call to Next returns
+// it again. It must be called with the token most recently returned by
+// Next, and at most once before the next call to Next.
+func (l *Lexer) Backup(token Token) {
+	l.buffered = token
+	l.hasBuffered = true
+}
+
+// Lex creates a pull-based Lexer for the given input and drains it on a
+// goroutine, emitting tokens on the returned channel in order, terminated
+// by a TokenEOF (or TokenError) token. The channel is closed once that
+// terminal token has been sent.
+//
+// It exists for callers that have not yet been converted to the
+// Lexer.Next/Peek/Backup API; new code should use NewLexer instead, which
+// scans tokens synchronously and without per-token channel handoff. Next
+// is not allocation-free: a token whose Value is its scanned text stores
+// that string in an interface{}, which allocates.
+func Lex(input string, source string) chan Token {
+	tokens := make(chan Token)
+	go func() {
+		defer close(tokens)
+		lexer := NewLexer(input, source)
+		for {
+			token := lexer.Next()
+			tokens <- token
+			switch token.Type {
+			case TokenEOF, TokenError:
+				return
+			}
+		}
+	}()
+	return tokens
+}
+
+// next returns the next rune in the input and advances the lexer's
+// position, or returns EOF if the input is exhausted. It always records
+// enough of its own effect (prevEndPos, prevWidth) for backup to undo it,
+// including when it returns EOF without consuming anything, so that
+// backup is safe to call unconditionally after any call to next.
+func (l *lexer) next() rune {
+	l.prevEndPos = l.endPos
+
+	if l.endOffset >= len(l.input) {
+		l.prevWidth = 0
+		return EOF
+	}
+
+	r, width := utf8.DecodeRuneInString(l.input[l.endOffset:])
+	l.endOffset += width
+	l.prevWidth = width
+
+	if r == '\n' {
+		l.endPos = l.endPos.AdvanceLine()
+	} else {
+		l.endPos = l.endPos.Shifted(1)
+	}
+	// Column counts runes, but Offset is a byte offset: a multi-byte rune
+	// advances it by its full width.
+	l.endPos.Offset = l.endOffset
+
+	return r
+}
+
+// backup undoes the effect of the most recent call to next.
+// It can only be called once per call to next.
+func (l *lexer) backup() {
+	l.endOffset -= l.prevWidth
+	l.endPos = l.prevEndPos
+}
+
+// accept consumes the next rune if it equals r, and reports whether it did.
+// If it does not, the rune is left unconsumed (as if next had not been
+// called).
+func (l *lexer) accept(r rune) bool {
+	if l.next() == r {
+		return true
+	}
+	l.backup()
+	return false
+}
+
+// emit records a token of the given type, with the given value, covering
+// the range from the start of the current token to the current position,
+// for scan to return, and advances the start of the next token to the
+// current position.
+func (l *lexer) emit(tokenType TokenType, value interface{}) {
+	l.tokenReady = true
+	l.pendingToken = Token{
+		Type:  tokenType,
+		Value: value,
+		Range: ast.Range{
+			StartPos: l.startPos,
+			EndPos:   l.endPos,
+		},
+	}
+	l.startOffset = l.endOffset
+	l.startPos = l.endPos
+}
+
+// emitError records a TokenError token covering the range of the current
+// token, from its start to the current position, and stops scanning by
+// returning a nil state.
+func (l *lexer) emitError(err error) stateFn {
+	l.emit(TokenError, err)
+	return nil
+}
+
+// word returns the portion of the input scanned for the current token.
+func (l *lexer) word() string {
+	return l.input[l.startOffset:l.endOffset]
+}
+
+// rootState is the initial, and by far the most common, state: it is
+// entered at the start of every token. It runs the DFA generated into
+// lexer_table.go (see gen/main.go), a tiny loop that walks
+// transitionTarget[state][byte] until a token is recognized.
+//
+// The one part of this grammar the table cannot drive is nested block
+// comments: recognizing properly nested "/* ... /* ... */ ... */"
+// requires counting nesting depth, and no finite automaton can count
+// (that's the classic pumping-lemma argument for why matched-nesting
+// languages aren't regular). So the table carries scanning up to the
+// opening "/*" and then hands off to blockCommentState, a small
+// hand-written counter loop, for the body of the comment.
+func rootState(l *lexer) stateFn {
+	state := stateStart
+
+	for {
+		r := l.next()
+
+		if r == EOF {
+			if state == stateStart {
+				l.emit(TokenEOF, nil)
+				return nil
+			}
+			return finishState(l, state, false)
+		}
+
+		var target lexState
+		if r >= 0 && r <= 255 {
+			target = transitionTarget[state][r]
+		}
+		if target == stateNone {
+			target = stateDefaultTarget[state]
+		}
+
+		switch target {
+		case stateStop:
+			return finishState(l, state, true)
+
+		case stateBlockCommentEntry:
+			return blockCommentState(l)
+
+		default:
+			state = target
+			if stateImmediate[state] {
+				l.emit(stateAcceptType[state], stateValue(l, state))
+				return rootState
+			}
+		}
+	}
+}
+
+// finishState is reached once the table has no transition left to take:
+// either the input ran out (consumed is false) or the rune just read
+// didn't extend the current state (consumed is true, so it must be
+// backed up before accepting or erroring on the token scanned so far).
+func finishState(l *lexer, state lexState, consumed bool) stateFn {
+	if consumed {
+		l.backup()
+	}
+
+	if !stateAccepts[state] {
+		message := stateErrorMessage[state]
+		if message == "" {
+			message = fmt.Sprintf("unexpected character: %#U", l.lastRune())
+		}
+		return l.emitError(errors.New(message))
+	}
+
+	l.emit(stateAcceptType[state], stateValue(l, state))
+	return rootState
+}
+
+// stateValue returns the Value an accepted token of the given state
+// should carry: the scanned text itself for states stateHasValue marks
+// (numbers, identifiers, strings, space, comments), and nil for every
+// other token, whose type already says everything about it.
+func stateValue(l *lexer, state lexState) interface{} {
+	if stateHasValue[state] {
+		return l.word()
+	}
+	return nil
+}
+
+// lastRune decodes the rune at the current end of the token being
+// scanned, for use in an "unexpected character" error message once
+// scanning of that rune's (failed) token has already stopped.
+func (l *lexer) lastRune() rune {
+	r, _ := utf8.DecodeRuneInString(l.input[l.endOffset:])
+	return r
+}
+
+// blockCommentState scans a block comment, from the leading "/*" up to
+// and including the matching "*/", and emits it as a single
+// TokenBlockComment token. Block comments may be nested; see rootState's
+// doc comment for why this, alone, isn't part of the generated table.
+func blockCommentState(l *lexer) stateFn {
+	depth := 1
+
+	for depth > 0 {
+		r := l.next()
+		switch {
+		case r == EOF:
+			return l.emitError(errors.New("missing comment end"))
+		case r == '/' && l.accept('*'):
+			depth++
+		case r == '*' && l.accept('/'):
+			depth--
+		}
+	}
+
+	l.emit(TokenBlockComment, l.word())
+	return rootState
+}
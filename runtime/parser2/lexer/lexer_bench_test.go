@@ -0,0 +1,100 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lexer
+
+import (
+	"testing"
+)
+
+// benchmarkInput is a small but representative snippet of source,
+// repeated a number of times to approximate a realistic program.
+//
+// It sticks to tokens the lexer currently supports: the language has
+// declarations with braces, colons, and commas, but the lexer doesn't
+// recognize those characters yet, so including them here would just
+// make both benchmarks below bail out early with a TokenError instead
+// of measuring a lex of the full repeated input.
+const benchmarkInput = `
+// computes a running total and greets the result
+total = 0
+total = total + 1
+total = total * 2 - 1
+
+greeting = "Hello, World!"
+
+/* nested /* block */ comment */
+isPositive = total > 0
+isBounded = total >= 0
+
+average = (total + 1) / 2
+scaled = 1_000_000 * 0.5e10 + 0x1F
+`
+
+func realisticSource(repetitions int) string {
+	source := ""
+	for i := 0; i < repetitions; i++ {
+		source += benchmarkInput
+	}
+	return source
+}
+
+// TestRealisticSourceLexesCleanly guards the benchmarks below against a
+// regression where benchmarkInput stops being valid input: a TokenError
+// partway through would silently turn BenchmarkLexChannel and
+// BenchmarkLexerNext into benchmarks of a small error prefix instead of
+// the full repeated source.
+func TestRealisticSourceLexesCleanly(t *testing.T) {
+	l := NewLexer(realisticSource(50), "test")
+	for {
+		token := l.Next()
+		if token.Type == TokenError {
+			t.Fatalf("unexpected error token: %v", token.Value)
+		}
+		if token.Type == TokenEOF {
+			break
+		}
+	}
+}
+
+func BenchmarkLexChannel(b *testing.B) {
+	input := realisticSource(50)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for range Lex(input, "test") {
+		}
+	}
+}
+
+func BenchmarkLexerNext(b *testing.B) {
+	input := realisticSource(50)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		l := NewLexer(input, "test")
+		for {
+			token := l.Next()
+			if token.Type == TokenEOF || token.Type == TokenError {
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lexer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// allTokens drains a Lexer by repeatedly calling Next, stopping once the
+// terminal TokenEOF (or TokenError) token has been returned.
+func allTokens(l *Lexer) []Token {
+	tokens := make([]Token, 0)
+	for {
+		token := l.Next()
+		tokens = append(tokens, token)
+		switch token.Type {
+		case TokenEOF, TokenError:
+			return tokens
+		}
+	}
+}
+
+func TestLexerNext(t *testing.T) {
+
+	t.Run("matches Lex", func(t *testing.T) {
+		const input = "(2 + 3) * 4"
+
+		withTokens(Lex(input, "test"), func(channelTokens []Token) {
+			assert.Equal(t, channelTokens, allTokens(NewLexer(input, "test")))
+		})
+	})
+
+	t.Run("Next after EOF keeps returning EOF", func(t *testing.T) {
+		l := NewLexer("", "test")
+		first := l.Next()
+		assert.Equal(t, TokenEOF, first.Type)
+		assert.Equal(t, first, l.Next())
+	})
+}
+
+func TestLexerPeek(t *testing.T) {
+
+	t.Run("does not consume the token", func(t *testing.T) {
+		l := NewLexer("1 2", "test")
+		peeked := l.Peek()
+		assert.Equal(t, TokenNumber, peeked.Type)
+		assert.Equal(t, peeked, l.Next())
+	})
+
+	t.Run("is idempotent", func(t *testing.T) {
+		l := NewLexer("1 2", "test")
+		assert.Equal(t, l.Peek(), l.Peek())
+	})
+}
+
+func TestLexerBackup(t *testing.T) {
+
+	t.Run("replays the token", func(t *testing.T) {
+		l := NewLexer("1 2", "test")
+		token := l.Next()
+		assert.Equal(t, TokenNumber, token.Type)
+		l.Backup(token)
+		assert.Equal(t, token, l.Next())
+	})
+}
@@ -0,0 +1,82 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lexer
+
+import "testing"
+
+// referenceStartState re-implements, directly from the character classes
+// a token can start with, which state transitionTarget[stateStart] should
+// send a given leading byte to. It is independent of the generated table,
+// so FuzzStartTransition can cross-check the generated table against it.
+func referenceStartState(b byte) lexState {
+	switch {
+	case b == ' ' || b == '\t' || b == '\n' || b == '\r':
+		return stateSpace
+	case b == '0':
+		return stateZero
+	case b >= '1' && b <= '9':
+		return stateDecInt
+	case b == '_' || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z'):
+		return stateIdentifier
+	case b == '"':
+		return stateString
+	case b == '(':
+		return stateParenOpen
+	case b == ')':
+		return stateParenClose
+	case b == '+':
+		return stateOpPlus
+	case b == '-':
+		return stateMinus
+	case b == '*':
+		return stateOpMul
+	case b == '/':
+		return stateSlash
+	case b == '?':
+		return stateQuestion
+	case b == '<':
+		return stateLess
+	case b == '>':
+		return stateGreater
+	case b == '=':
+		return stateEqual
+	case b == '!':
+		return stateBang
+	default:
+		return stateNone
+	}
+}
+
+func FuzzStartTransition(f *testing.F) {
+	for _, seed := range []byte{
+		'a', 'Z', '_', '0', '9', ' ', '\t', '\n', '(', ')',
+		'+', '-', '*', '/', '?', '<', '>', '=', '!', '"',
+		0, 255, '{', ',',
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, b uint8) {
+		got := transitionTarget[stateStart][b]
+		want := referenceStartState(b)
+		if got != want {
+			t.Fatalf("transitionTarget[stateStart][%q] = %v, want %v (from reference dispatcher)", b, got, want)
+		}
+	})
+}
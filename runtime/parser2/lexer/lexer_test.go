@@ -39,10 +39,21 @@ func withTokens(tokenChan chan Token, fn func([]Token)) {
 	}
 }
 
+// typesAndValues strips the Range of each token, for tests that are only
+// concerned with which tokens were produced, and with what value, not
+// with their exact positions.
+func typesAndValues(tokens []Token) []Token {
+	stripped := make([]Token, len(tokens))
+	for i, token := range tokens {
+		stripped[i] = Token{Type: token.Type, Value: token.Value}
+	}
+	return stripped
+}
+
 func TestLex(t *testing.T) {
 
 	t.Run("single char number", func(t *testing.T) {
-		withTokens(Lex("0"), func(tokens []Token) {
+		withTokens(Lex("0", "test"), func(tokens []Token) {
 			assert.Equal(t,
 				[]Token{
 					{
@@ -67,7 +78,7 @@ func TestLex(t *testing.T) {
 	})
 
 	t.Run("two char number", func(t *testing.T) {
-		withTokens(Lex("01"), func(tokens []Token) {
+		withTokens(Lex("01", "test"), func(tokens []Token) {
 			assert.Equal(t,
 				[]Token{
 					{
@@ -92,7 +103,7 @@ func TestLex(t *testing.T) {
 	})
 
 	t.Run("two numbers separated by whitespace", func(t *testing.T) {
-		withTokens(Lex(" 01\t  10"), func(tokens []Token) {
+		withTokens(Lex(" 01\t  10", "test"), func(tokens []Token) {
 			assert.Equal(t,
 				[]Token{
 					{
@@ -142,7 +153,7 @@ func TestLex(t *testing.T) {
 	})
 
 	t.Run("simple arithmetic", func(t *testing.T) {
-		withTokens(Lex("(2 + 3) * 4"), func(tokens []Token) {
+		withTokens(Lex("(2 + 3) * 4", "test"), func(tokens []Token) {
 			assert.Equal(t,
 				[]Token{
 					{
@@ -243,7 +254,7 @@ func TestLex(t *testing.T) {
 	})
 
 	t.Run("multiple lines", func(t *testing.T) {
-		withTokens(Lex("1 \n  2\n"), func(tokens []Token) {
+		withTokens(Lex("1 \n  2\n", "test"), func(tokens []Token) {
 			assert.Equal(t,
 				[]Token{
 					{
@@ -292,7 +303,7 @@ func TestLex(t *testing.T) {
 	})
 
 	t.Run("nil-coalesce", func(t *testing.T) {
-		withTokens(Lex("1 ?? 2"), func(tokens []Token) {
+		withTokens(Lex("1 ?? 2", "test"), func(tokens []Token) {
 			assert.Equal(t,
 				[]Token{
 					{
@@ -348,7 +359,7 @@ func TestLex(t *testing.T) {
 	})
 
 	t.Run("invalid nil-coalesce", func(t *testing.T) {
-		withTokens(Lex("1 ?X"), func(tokens []Token) {
+		withTokens(Lex("1 ?X", "test"), func(tokens []Token) {
 			assert.Equal(t,
 				[]Token{
 					{
@@ -382,7 +393,7 @@ func TestLex(t *testing.T) {
 	})
 
 	t.Run("identifier", func(t *testing.T) {
-		withTokens(Lex("test"), func(tokens []Token) {
+		withTokens(Lex("test", "test"), func(tokens []Token) {
 			assert.Equal(t,
 				[]Token{
 					{
@@ -407,7 +418,7 @@ func TestLex(t *testing.T) {
 	})
 
 	t.Run("identifier with leading underscore and trailing numbers", func(t *testing.T) {
-		withTokens(Lex("_test_123"), func(tokens []Token) {
+		withTokens(Lex("_test_123", "test"), func(tokens []Token) {
 			assert.Equal(t,
 				[]Token{
 					{
@@ -431,4 +442,428 @@ func TestLex(t *testing.T) {
 		})
 	})
 
-}
\ No newline at end of file
+	t.Run("division", func(t *testing.T) {
+		withTokens(Lex("1 / 2", "test"), func(tokens []Token) {
+			assert.Equal(t,
+				[]Token{
+					{
+						Type:  TokenNumber,
+						Value: "1",
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+							EndPos:   ast.Position{Line: 1, Column: 1, Offset: 1},
+						},
+					},
+					{
+						Type:  TokenSpace,
+						Value: " ",
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 1, Offset: 1},
+							EndPos:   ast.Position{Line: 1, Column: 2, Offset: 2},
+						},
+					},
+					{
+						Type: TokenOperatorDiv,
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 2, Offset: 2},
+							EndPos:   ast.Position{Line: 1, Column: 3, Offset: 3},
+						},
+					},
+					{
+						Type:  TokenSpace,
+						Value: " ",
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 3, Offset: 3},
+							EndPos:   ast.Position{Line: 1, Column: 4, Offset: 4},
+						},
+					},
+					{
+						Type:  TokenNumber,
+						Value: "2",
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 4, Offset: 4},
+							EndPos:   ast.Position{Line: 1, Column: 5, Offset: 5},
+						},
+					},
+					{
+						Type: TokenEOF,
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 5, Offset: 5},
+							EndPos:   ast.Position{Line: 1, Column: 5, Offset: 5},
+						},
+					},
+				},
+				tokens,
+			)
+		})
+	})
+
+	t.Run("line comment", func(t *testing.T) {
+		withTokens(Lex("// foo\n1", "test"), func(tokens []Token) {
+			assert.Equal(t,
+				[]Token{
+					{
+						Type:  TokenLineComment,
+						Value: "// foo",
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+							EndPos:   ast.Position{Line: 1, Column: 6, Offset: 6},
+						},
+					},
+					{
+						Type:  TokenSpace,
+						Value: "\n",
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 6, Offset: 6},
+							EndPos:   ast.Position{Line: 2, Column: 0, Offset: 7},
+						},
+					},
+					{
+						Type:  TokenNumber,
+						Value: "1",
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 2, Column: 0, Offset: 7},
+							EndPos:   ast.Position{Line: 2, Column: 1, Offset: 8},
+						},
+					},
+					{
+						Type: TokenEOF,
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 2, Column: 1, Offset: 8},
+							EndPos:   ast.Position{Line: 2, Column: 1, Offset: 8},
+						},
+					},
+				},
+				tokens,
+			)
+		})
+	})
+
+	t.Run("line comment at end of input", func(t *testing.T) {
+		withTokens(Lex("// foo", "test"), func(tokens []Token) {
+			assert.Equal(t,
+				[]Token{
+					{
+						Type:  TokenLineComment,
+						Value: "// foo",
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+							EndPos:   ast.Position{Line: 1, Column: 6, Offset: 6},
+						},
+					},
+					{
+						Type: TokenEOF,
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 6, Offset: 6},
+							EndPos:   ast.Position{Line: 1, Column: 6, Offset: 6},
+						},
+					},
+				},
+				tokens,
+			)
+		})
+	})
+
+	t.Run("block comment", func(t *testing.T) {
+		withTokens(Lex("/* foo */1", "test"), func(tokens []Token) {
+			assert.Equal(t,
+				[]Token{
+					{
+						Type:  TokenBlockComment,
+						Value: "/* foo */",
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+							EndPos:   ast.Position{Line: 1, Column: 9, Offset: 9},
+						},
+					},
+					{
+						Type:  TokenNumber,
+						Value: "1",
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 9, Offset: 9},
+							EndPos:   ast.Position{Line: 1, Column: 10, Offset: 10},
+						},
+					},
+					{
+						Type: TokenEOF,
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 10, Offset: 10},
+							EndPos:   ast.Position{Line: 1, Column: 10, Offset: 10},
+						},
+					},
+				},
+				tokens,
+			)
+		})
+	})
+
+	t.Run("nested block comment", func(t *testing.T) {
+		withTokens(Lex("/* a /* b */ c */1", "test"), func(tokens []Token) {
+			assert.Equal(t,
+				[]Token{
+					{
+						Type:  TokenBlockComment,
+						Value: "/* a /* b */ c */",
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+							EndPos:   ast.Position{Line: 1, Column: 17, Offset: 17},
+						},
+					},
+					{
+						Type:  TokenNumber,
+						Value: "1",
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 17, Offset: 17},
+							EndPos:   ast.Position{Line: 1, Column: 18, Offset: 18},
+						},
+					},
+					{
+						Type: TokenEOF,
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 18, Offset: 18},
+							EndPos:   ast.Position{Line: 1, Column: 18, Offset: 18},
+						},
+					},
+				},
+				tokens,
+			)
+		})
+	})
+
+	t.Run("unterminated block comment", func(t *testing.T) {
+		withTokens(Lex("/* foo", "test"), func(tokens []Token) {
+			assert.Equal(t,
+				[]Token{
+					{
+						Type:  TokenError,
+						Value: errors.New("missing comment end"),
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+							EndPos:   ast.Position{Line: 1, Column: 6, Offset: 6},
+						},
+					},
+				},
+				tokens,
+			)
+		})
+	})
+
+	t.Run("string literal", func(t *testing.T) {
+		withTokens(Lex(`"hello"`, "test"), func(tokens []Token) {
+			assert.Equal(t,
+				[]Token{
+					{Type: TokenString, Value: `"hello"`},
+					{Type: TokenEOF},
+				},
+				typesAndValues(tokens),
+			)
+		})
+	})
+
+	t.Run("string literal with escaped quote", func(t *testing.T) {
+		withTokens(Lex(`"a \" b"`, "test"), func(tokens []Token) {
+			assert.Equal(t,
+				[]Token{
+					{Type: TokenString, Value: `"a \" b"`},
+					{Type: TokenEOF},
+				},
+				typesAndValues(tokens),
+			)
+		})
+	})
+
+	t.Run("unterminated string literal", func(t *testing.T) {
+		withTokens(Lex(`"hello`, "test"), func(tokens []Token) {
+			assert.Equal(t,
+				[]Token{
+					{Type: TokenError, Value: errors.New("missing string end")},
+				},
+				typesAndValues(tokens),
+			)
+		})
+	})
+
+	t.Run("hex number literal", func(t *testing.T) {
+		withTokens(Lex("0xf2", "test"), func(tokens []Token) {
+			assert.Equal(t,
+				[]Token{
+					{Type: TokenNumber, Value: "0xf2"},
+					{Type: TokenEOF},
+				},
+				typesAndValues(tokens),
+			)
+		})
+	})
+
+	t.Run("binary number literal", func(t *testing.T) {
+		withTokens(Lex("0b101", "test"), func(tokens []Token) {
+			assert.Equal(t,
+				[]Token{
+					{Type: TokenNumber, Value: "0b101"},
+					{Type: TokenEOF},
+				},
+				typesAndValues(tokens),
+			)
+		})
+	})
+
+	t.Run("octal number literal", func(t *testing.T) {
+		withTokens(Lex("0o17", "test"), func(tokens []Token) {
+			assert.Equal(t,
+				[]Token{
+					{Type: TokenNumber, Value: "0o17"},
+					{Type: TokenEOF},
+				},
+				typesAndValues(tokens),
+			)
+		})
+	})
+
+	t.Run("number literal with underscore digit separators", func(t *testing.T) {
+		withTokens(Lex("1_000_000", "test"), func(tokens []Token) {
+			assert.Equal(t,
+				[]Token{
+					{Type: TokenNumber, Value: "1_000_000"},
+					{Type: TokenEOF},
+				},
+				typesAndValues(tokens),
+			)
+		})
+	})
+
+	t.Run("number literal with fractional part", func(t *testing.T) {
+		withTokens(Lex("1.5", "test"), func(tokens []Token) {
+			assert.Equal(t,
+				[]Token{
+					{Type: TokenNumber, Value: "1.5"},
+					{Type: TokenEOF},
+				},
+				typesAndValues(tokens),
+			)
+		})
+	})
+
+	t.Run("number literal with exponent", func(t *testing.T) {
+		withTokens(Lex("1.5e-10", "test"), func(tokens []Token) {
+			assert.Equal(t,
+				[]Token{
+					{Type: TokenNumber, Value: "1.5e-10"},
+					{Type: TokenEOF},
+				},
+				typesAndValues(tokens),
+			)
+		})
+	})
+
+	t.Run("arrow", func(t *testing.T) {
+		withTokens(Lex("-> <-", "test"), func(tokens []Token) {
+			assert.Equal(t,
+				[]Token{
+					{Type: TokenArrow},
+					{Type: TokenSpace, Value: " "},
+					{Type: TokenLeftArrow},
+					{Type: TokenEOF},
+				},
+				typesAndValues(tokens),
+			)
+		})
+	})
+
+	t.Run("minus without arrow", func(t *testing.T) {
+		withTokens(Lex("1-2", "test"), func(tokens []Token) {
+			assert.Equal(t,
+				[]Token{
+					{Type: TokenNumber, Value: "1"},
+					{Type: TokenOperatorMinus},
+					{Type: TokenNumber, Value: "2"},
+					{Type: TokenEOF},
+				},
+				typesAndValues(tokens),
+			)
+		})
+	})
+
+	t.Run("less than family", func(t *testing.T) {
+		withTokens(Lex("< <= <<", "test"), func(tokens []Token) {
+			assert.Equal(t,
+				[]Token{
+					{Type: TokenLess},
+					{Type: TokenSpace, Value: " "},
+					{Type: TokenLessEqual},
+					{Type: TokenSpace, Value: " "},
+					{Type: TokenShiftLeft},
+					{Type: TokenEOF},
+				},
+				typesAndValues(tokens),
+			)
+		})
+	})
+
+	t.Run("greater than family", func(t *testing.T) {
+		withTokens(Lex("> >= >>", "test"), func(tokens []Token) {
+			assert.Equal(t,
+				[]Token{
+					{Type: TokenGreater},
+					{Type: TokenSpace, Value: " "},
+					{Type: TokenGreaterEqual},
+					{Type: TokenSpace, Value: " "},
+					{Type: TokenShiftRight},
+					{Type: TokenEOF},
+				},
+				typesAndValues(tokens),
+			)
+		})
+	})
+
+	t.Run("equal family", func(t *testing.T) {
+		withTokens(Lex("= ==", "test"), func(tokens []Token) {
+			assert.Equal(t,
+				[]Token{
+					{Type: TokenEqual},
+					{Type: TokenSpace, Value: " "},
+					{Type: TokenEqualEqual},
+					{Type: TokenEOF},
+				},
+				typesAndValues(tokens),
+			)
+		})
+	})
+
+	t.Run("bang family", func(t *testing.T) {
+		withTokens(Lex("! !=", "test"), func(tokens []Token) {
+			assert.Equal(t,
+				[]Token{
+					{Type: TokenNot},
+					{Type: TokenSpace, Value: " "},
+					{Type: TokenNotEqual},
+					{Type: TokenEOF},
+				},
+				typesAndValues(tokens),
+			)
+		})
+	})
+
+	t.Run("multiple files get distinct FileIDs", func(t *testing.T) {
+		withTokens(Lex("0", "a.cdc"), func(aTokens []Token) {
+			withTokens(Lex("0", "b.cdc"), func(bTokens []Token) {
+				aFile := aTokens[0].Range.StartPos.FileID
+				bFile := bTokens[0].Range.StartPos.FileID
+				assert.NotEqual(t, aFile, bFile)
+				assert.Equal(t, "a.cdc", SourceName(aFile))
+				assert.Equal(t, "b.cdc", SourceName(bFile))
+			})
+		})
+	})
+
+	t.Run("same source name is interned once", func(t *testing.T) {
+		withTokens(Lex("0", "same.cdc"), func(firstTokens []Token) {
+			withTokens(Lex("1", "same.cdc"), func(secondTokens []Token) {
+				assert.Equal(t,
+					firstTokens[0].Range.StartPos.FileID,
+					secondTokens[0].Range.StartPos.FileID,
+				)
+			})
+		})
+	})
+
+}
@@ -0,0 +1,72 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lexer
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+)
+
+// TokenType is the type of a lexical token.
+type TokenType int
+
+const (
+	TokenError TokenType = iota
+	TokenEOF
+
+	TokenSpace
+	TokenLineComment
+	TokenBlockComment
+
+	TokenIdentifier
+	TokenNumber
+	TokenString
+
+	TokenParenOpen
+	TokenParenClose
+
+	TokenOperatorPlus
+	TokenOperatorMinus
+	TokenOperatorMul
+	TokenOperatorDiv
+
+	TokenOperatorNilCoalesce
+
+	TokenArrow     // ->
+	TokenLeftArrow // <-
+
+	TokenLess         // <
+	TokenLessEqual    // <=
+	TokenShiftLeft    // <<
+	TokenGreater      // >
+	TokenGreaterEqual // >=
+	TokenShiftRight   // >>
+
+	TokenEqual      // =
+	TokenEqualEqual // ==
+	TokenNot        // !
+	TokenNotEqual   // !=
+)
+
+// Token represents a single lexical token, with its type, an optional value,
+// and the range it occupies in the source.
+type Token struct {
+	Type  TokenType
+	Value interface{}
+	Range ast.Range
+}
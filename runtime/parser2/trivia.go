@@ -0,0 +1,122 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser2
+
+import (
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/parser2/lexer"
+)
+
+// AttachedToken pairs a significant (non-trivia) token with the
+// free-floating trivia -- whitespace and comments -- that immediately
+// precedes it in the source.
+type AttachedToken struct {
+	Token   lexer.Token
+	Leading []ast.Trivia
+}
+
+// isTrivia reports whether a token carries no syntactic meaning of its
+// own: whitespace or a comment.
+func isTrivia(tokenType lexer.TokenType) bool {
+	switch tokenType {
+	case lexer.TokenSpace, lexer.TokenLineComment, lexer.TokenBlockComment:
+		return true
+	default:
+		return false
+	}
+}
+
+func triviaType(tokenType lexer.TokenType) ast.TriviaType {
+	switch tokenType {
+	case lexer.TokenLineComment:
+		return ast.TriviaLineComment
+	case lexer.TokenBlockComment:
+		return ast.TriviaBlockComment
+	default:
+		return ast.TriviaSpace
+	}
+}
+
+// AttachTrivia lexes the given input and groups each run of whitespace
+// and comments with the significant token that immediately follows it.
+//
+// This is the layer a future AST-building parser would use to avoid
+// simply discarding trivia the way it would by only looking at
+// significant tokens: once that parser builds AST nodes from the
+// returned tokens, it would attach each token's Leading trivia to the
+// node with ast.FreeFloating.SetFreeFloating, so that tools built on the
+// AST (formatters, linters, refactoring tools) can recover the user's
+// original comments and layout. No such parser exists yet in this
+// package, so AttachTrivia's own result -- a flat slice of tokens paired
+// with their leading trivia -- is as far as that attachment goes today.
+func AttachTrivia(input string, source string) []AttachedToken {
+	l := lexer.NewLexer(input, source)
+
+	var attached []AttachedToken
+	var leading []ast.Trivia
+
+	for {
+		token := l.Next()
+
+		if isTrivia(token.Type) {
+			value, _ := token.Value.(string)
+			leading = append(leading, ast.Trivia{
+				Type:  triviaType(token.Type),
+				Value: value,
+				Range: token.Range,
+			})
+			continue
+		}
+
+		attached = append(attached, AttachedToken{
+			Token:   token,
+			Leading: leading,
+		})
+		leading = nil
+
+		switch token.Type {
+		case lexer.TokenEOF, lexer.TokenError:
+			return attached
+		}
+	}
+}
+
+// Print reconstructs the exact original source, byte for byte, from the
+// tokens AttachTrivia produced for it. It is a plain function over that
+// token slice, not a visitor over AST nodes: since every trivia and token
+// range is sliced directly out of input, round-tripping is exact
+// regardless of how a particular token's Value was recorded. For input
+// that fails to lex, the tokens end at the TokenError token, and the
+// source after it is not reproduced.
+func Print(input string, tokens []AttachedToken) string {
+	var b strings.Builder
+
+	for _, attached := range tokens {
+		for _, trivia := range attached.Leading {
+			b.WriteString(input[trivia.Range.StartPos.Offset:trivia.Range.EndPos.Offset])
+		}
+
+		tokenRange := attached.Token.Range
+		b.WriteString(input[tokenRange.StartPos.Offset:tokenRange.EndPos.Offset])
+	}
+
+	return b.String()
+}
@@ -0,0 +1,93 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/parser2/lexer"
+)
+
+func TestAttachTrivia(t *testing.T) {
+
+	t.Run("attaches leading whitespace and comments to the following token", func(t *testing.T) {
+		const input = "  // a comment\n1 + 2"
+
+		attached := AttachTrivia(input, "test")
+
+		assert.Len(t, attached, 4) // "1", "+", "2", EOF
+
+		first := attached[0]
+		assert.Equal(t, lexer.TokenNumber, first.Token.Type)
+		assert.Equal(t,
+			[]ast.Trivia{
+				{Type: ast.TriviaSpace, Value: "  "},
+				{Type: ast.TriviaLineComment, Value: "// a comment"},
+				{Type: ast.TriviaSpace, Value: "\n"},
+			},
+			stripRanges(first.Leading),
+		)
+
+		second := attached[1]
+		assert.Equal(t, lexer.TokenOperatorPlus, second.Token.Type)
+		assert.Equal(t, []ast.Trivia{{Type: ast.TriviaSpace, Value: " "}}, stripRanges(second.Leading))
+	})
+
+	t.Run("no leading trivia", func(t *testing.T) {
+		attached := AttachTrivia("1", "test")
+		assert.Empty(t, attached[0].Leading)
+	})
+
+	t.Run("tokens carry the FileID of the source they were attached from", func(t *testing.T) {
+		a := AttachTrivia("1", "a.cdc")
+		b := AttachTrivia("1", "b.cdc")
+		assert.NotEqual(t,
+			a[0].Token.Range.StartPos.FileID,
+			b[0].Token.Range.StartPos.FileID,
+		)
+	})
+}
+
+func TestPrint(t *testing.T) {
+
+	for _, input := range []string{
+		"1 + 2",
+		"  // leading comment\n1 + 2  ",
+		"/* a /* nested */ comment */1",
+		"1 ?? 2",
+	} {
+		t.Run(input, func(t *testing.T) {
+			attached := AttachTrivia(input, "test")
+			assert.Equal(t, input, Print(input, attached))
+		})
+	}
+}
+
+// stripRanges clears the Range of each trivia, so tests can compare just
+// the type and value without hard-coding positions.
+func stripRanges(trivia []ast.Trivia) []ast.Trivia {
+	stripped := make([]ast.Trivia, len(trivia))
+	for i, t := range trivia {
+		stripped[i] = ast.Trivia{Type: t.Type, Value: t.Value}
+	}
+	return stripped
+}